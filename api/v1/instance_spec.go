@@ -0,0 +1,47 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LocationSpec describes one of a component's on-disk data directories,
+// which gets created by an init container before the main process starts.
+type LocationSpec struct {
+	LocationType string `json:"locationType"`
+	Path         string `json:"path"`
+}
+
+// InstanceSpec is the set of fields shared by every YT server component
+// (master, scheduler, controller agent, ...). A component's effective
+// InstanceSpec is merge.InstanceSpec(Spec.Defaults.InstanceSpec,
+// <component>.InstanceSpec), so most fields only need setting once under
+// Spec.Defaults.
+type InstanceSpec struct {
+	InstanceCount int32 `json:"instanceCount"`
+
+	Image *string `json:"image,omitempty"`
+
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	Affinity     *corev1.Affinity    `json:"affinity,omitempty"`
+	NodeSelector map[string]string   `json:"nodeSelector,omitempty"`
+	Tolerations  []corev1.Toleration `json:"tolerations,omitempty"`
+
+	Locations            []LocationSpec                 `json:"locations,omitempty"`
+	Volumes              []corev1.Volume                `json:"volumes,omitempty"`
+	VolumeMounts         []corev1.VolumeMount            `json:"volumeMounts,omitempty"`
+	VolumeClaimTemplates []corev1.PersistentVolumeClaim `json:"volumeClaimTemplates,omitempty"`
+
+	// UpdateStrategy overrides the StatefulSet controller's default rolling
+	// update, which rolls every replica, with a partitioned canary rollout.
+	UpdateStrategy *UpdateStrategy `json:"updateStrategy,omitempty"`
+
+	// MonitoringPort overrides the default Orchid monitoring port deep
+	// readiness probes are sent to.
+	MonitoringPort *int32 `json:"monitoringPort,omitempty"`
+
+	// ReadinessTimeout overrides how long a deep readiness probe is allowed
+	// to take before it's considered failed.
+	ReadinessTimeout *metav1.Duration `json:"readinessTimeout,omitempty"`
+}
@@ -0,0 +1,33 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// UpdateStrategyType selects how a component rolls out a spec/image change.
+type UpdateStrategyType string
+
+const (
+	// UpdateStrategyCanary rolls the top Partition replicas first, gates on
+	// their deep readiness, then steps the rest down MaxUnavailable at a
+	// time instead of letting one rolling update reach every replica.
+	UpdateStrategyCanary UpdateStrategyType = "Canary"
+)
+
+// UpdateStrategy configures a partitioned canary rollout for a component.
+type UpdateStrategy struct {
+	Type UpdateStrategyType `json:"type"`
+
+	// Partition is the number of replicas, counting down from the highest
+	// ordinal, that make up the canary wave.
+	Partition int32 `json:"partition"`
+
+	// MaxUnavailable bounds how many additional replicas roll to the new
+	// template per step once the canary wave is healthy and unpaused.
+	MaxUnavailable int32 `json:"maxUnavailable"`
+
+	// PauseAfterCanary, if set, holds the rollout at the canary wave for
+	// this long after it first passes its deep readiness probe, giving an
+	// operator a window to inspect it before the rest of the fleet rolls.
+	PauseAfterCanary *metav1.Duration `json:"pauseAfterCanary,omitempty"`
+}
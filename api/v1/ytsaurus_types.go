@@ -0,0 +1,95 @@
+// Package v1 contains the YTsaurus custom resource API types.
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ClusterState reports where a Ytsaurus resource is in its lifecycle.
+type ClusterState string
+
+const (
+	ClusterStateRunning  ClusterState = "Running"
+	ClusterStateUpdating ClusterState = "Updating"
+)
+
+// IsReadyToUpdateClusterState reports whether a component may request a
+// local update given the cluster's current top-level state: only once the
+// cluster itself isn't already mid-update.
+func IsReadyToUpdateClusterState(state ClusterState) bool {
+	return state == ClusterStateRunning
+}
+
+// Ytsaurus is the top-level custom resource describing a YTsaurus cluster.
+type Ytsaurus struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   YtsaurusSpec   `json:"spec,omitempty"`
+	Status YtsaurusStatus `json:"status,omitempty"`
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *Ytsaurus) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+
+// YtsaurusSpec is the desired state of a Ytsaurus cluster.
+type YtsaurusSpec struct {
+	CoreImage        string                        `json:"coreImage,omitempty"`
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	ConfigOverrides  []string                      `json:"configOverrides,omitempty"`
+
+	// PauseCondition halts any in-progress canary rollout once its canary
+	// wave has gone healthy, until an operator clears it.
+	PauseCondition *bool `json:"pauseCondition,omitempty"`
+
+	Defaults         ComponentSpec `json:"defaults,omitempty"`
+	ControllerAgents ComponentSpec `json:"controllerAgents,omitempty"`
+}
+
+// ComponentSpec wraps the shared InstanceSpec fields for a single entry
+// under YtsaurusSpec (Defaults, ControllerAgents, ...).
+type ComponentSpec struct {
+	InstanceSpec `json:",inline"`
+}
+
+// YtsaurusStatus is the observed state of a Ytsaurus cluster.
+type YtsaurusStatus struct {
+	Components map[string]ComponentStatus `json:"components,omitempty"`
+
+	// EffectiveSpec surfaces the defaults-merged InstanceSpec actually
+	// applied to each component, so `kubectl get ytsaurus -o yaml` shows it
+	// directly instead of operators having to mentally merge Spec.Defaults
+	// on top of each component's override by hand.
+	EffectiveSpec *EffectiveSpec `json:"effectiveSpec,omitempty"`
+}
+
+// EffectiveSpec is the per-component InstanceSpec actually applied, after
+// merging Spec.Defaults.InstanceSpec with the component's own override.
+type EffectiveSpec struct {
+	ControllerAgents InstanceSpec `json:"controllerAgents,omitempty"`
+}
+
+// ComponentStatus reports a single component's sync state.
+type ComponentStatus struct {
+	SyncStatus string `json:"syncStatus,omitempty"`
+	Message    string `json:"message,omitempty"`
+
+	// Conditions lets `kubectl wait --for=condition=` and Conditions-aware
+	// monitoring observe why a component is or isn't ready, beyond the flat
+	// SyncStatus/Message pair above.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// RolloutPartition is the StatefulSet partition a canary rollout has
+	// advanced to. nil means no canary rollout is in progress for this
+	// component.
+	RolloutPartition *int32 `json:"rolloutPartition,omitempty"`
+
+	// CanaryHealthySince records when the canary wave first passed its deep
+	// readiness probe, so UpdateStrategy.PauseAfterCanary can be measured
+	// from that moment rather than from every reconcile that re-observes it.
+	CanaryHealthySince *metav1.Time `json:"canaryHealthySince,omitempty"`
+}
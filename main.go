@@ -0,0 +1,30 @@
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/ytsaurus/yt-k8s-operator/pkg/log"
+)
+
+func main() {
+	var logLevel string
+	var logJSON bool
+	var logAddCaller bool
+
+	flag.StringVar(&logLevel, "log-level", "info", "Log level: debug, info, warn, error.")
+	flag.BoolVar(&logJSON, "log-json", false, "Emit logs as JSON instead of a human-readable console format.")
+	flag.BoolVar(&logAddCaller, "log-add-caller", false, "Annotate log lines with the file:line they were emitted from.")
+	flag.Parse()
+
+	if err := log.Configure(log.Options{
+		LogLevel:  logLevel,
+		JSON:      logJSON,
+		AddCaller: logAddCaller,
+	}); err != nil {
+		os.Exit(1)
+	}
+
+	// TODO: bootstrap the controller-runtime manager and register
+	// reconcilers here; tracked separately from the logging changeover.
+}
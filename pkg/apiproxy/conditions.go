@@ -0,0 +1,35 @@
+package apiproxy
+
+import (
+	"time"
+
+	ytv1 "github.com/ytsaurus/yt-k8s-operator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SetComponentCondition records cond on status.Components[name].Conditions,
+// following the meta/v1 Condition convention: LastTransitionTime is only
+// bumped when Status actually flips from what was already recorded, not on
+// every reconcile that merely reaffirms the same state.
+func SetComponentCondition(status *ytv1.YtsaurusStatus, name string, cond metav1.Condition) {
+	if status.Components == nil {
+		status.Components = make(map[string]ytv1.ComponentStatus)
+	}
+	component := status.Components[name]
+
+	cond.LastTransitionTime = metav1.NewTime(time.Now())
+	for i, existing := range component.Conditions {
+		if existing.Type != cond.Type {
+			continue
+		}
+		if existing.Status == cond.Status {
+			cond.LastTransitionTime = existing.LastTransitionTime
+		}
+		component.Conditions[i] = cond
+		status.Components[name] = component
+		return
+	}
+
+	component.Conditions = append(component.Conditions, cond)
+	status.Components[name] = component
+}
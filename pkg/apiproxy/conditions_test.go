@@ -0,0 +1,48 @@
+package apiproxy
+
+import (
+	"testing"
+	"time"
+
+	ytv1 "github.com/ytsaurus/yt-k8s-operator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetComponentConditionKeepsTransitionTimeUntilStatusFlips(t *testing.T) {
+	status := &ytv1.YtsaurusStatus{}
+
+	SetComponentCondition(status, "ControllerAgent", metav1.Condition{
+		Type:    "ControllerAgentReady",
+		Status:  metav1.ConditionFalse,
+		Reason:  "PodsNotReady",
+		Message: "waiting for pods",
+	})
+	firstTransition := status.Components["ControllerAgent"].Conditions[0].LastTransitionTime
+
+	time.Sleep(time.Millisecond)
+	SetComponentCondition(status, "ControllerAgent", metav1.Condition{
+		Type:    "ControllerAgentReady",
+		Status:  metav1.ConditionFalse,
+		Reason:  "PodsNotReady",
+		Message: "still waiting for pods",
+	})
+	unchanged := status.Components["ControllerAgent"].Conditions[0]
+	if !unchanged.LastTransitionTime.Equal(&firstTransition) {
+		t.Fatalf("expected LastTransitionTime to stay put when Status doesn't flip")
+	}
+	if unchanged.Message != "still waiting for pods" {
+		t.Fatalf("expected Message to still be updated even when Status doesn't flip")
+	}
+
+	time.Sleep(time.Millisecond)
+	SetComponentCondition(status, "ControllerAgent", metav1.Condition{
+		Type:    "ControllerAgentReady",
+		Status:  metav1.ConditionTrue,
+		Reason:  "ComponentReady",
+		Message: "component is ready",
+	})
+	flipped := status.Components["ControllerAgent"].Conditions[0]
+	if flipped.LastTransitionTime.Equal(&firstTransition) {
+		t.Fatalf("expected LastTransitionTime to advance when Status flips")
+	}
+}
@@ -0,0 +1,88 @@
+package apiproxy
+
+import (
+	ytv1 "github.com/ytsaurus/yt-k8s-operator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetComponentPartition returns the StatefulSet partition last recorded for
+// a component's canary rollout, or defaultPartition if the rollout hasn't
+// recorded any progress yet (e.g. it just started). Persisting the
+// partition in status is what lets a restarted operator resume a rollout
+// instead of starting over from the full canary wave.
+func GetComponentPartition(status *ytv1.YtsaurusStatus, name string, defaultPartition int32) int32 {
+	if status.Components == nil {
+		return defaultPartition
+	}
+	component, ok := status.Components[name]
+	if !ok || component.RolloutPartition == nil {
+		return defaultPartition
+	}
+	return *component.RolloutPartition
+}
+
+// SetComponentPartition records the StatefulSet partition a component's
+// canary rollout has advanced to.
+func SetComponentPartition(status *ytv1.YtsaurusStatus, name string, partition int32) {
+	if status.Components == nil {
+		status.Components = make(map[string]ytv1.ComponentStatus)
+	}
+	component := status.Components[name]
+	component.RolloutPartition = &partition
+	status.Components[name] = component
+}
+
+// ClearComponentPartition removes a completed rollout's persisted partition,
+// so the next time this component enters ClusterStateUpdating,
+// GetComponentPartition falls through to the caller's canaryFloor default
+// instead of reading back the stale partition (typically 0) the previous
+// rollout finished at.
+func ClearComponentPartition(status *ytv1.YtsaurusStatus, name string) {
+	if status.Components == nil {
+		return
+	}
+	component := status.Components[name]
+	component.RolloutPartition = nil
+	status.Components[name] = component
+}
+
+// GetComponentCanaryHealthySince returns when a component's canary wave
+// first passed its deep readiness probe during the current rollout, or nil
+// if that hasn't happened yet.
+func GetComponentCanaryHealthySince(status *ytv1.YtsaurusStatus, name string) *metav1.Time {
+	if status.Components == nil {
+		return nil
+	}
+	return status.Components[name].CanaryHealthySince
+}
+
+// SetComponentCanaryHealthySince records when a component's canary wave
+// first passed its deep readiness probe, so UpdateStrategy.PauseAfterCanary
+// can be measured from that moment across reconciles.
+func SetComponentCanaryHealthySince(status *ytv1.YtsaurusStatus, name string, t metav1.Time) {
+	if status.Components == nil {
+		status.Components = make(map[string]ytv1.ComponentStatus)
+	}
+	component := status.Components[name]
+	component.CanaryHealthySince = &t
+	status.Components[name] = component
+}
+
+// ClearComponentCanaryHealthySince removes the persisted canary-healthy
+// timestamp, so the next rollout measures PauseAfterCanary from its own
+// canary wave going healthy rather than a previous rollout's.
+func ClearComponentCanaryHealthySince(status *ytv1.YtsaurusStatus, name string) {
+	if status.Components == nil {
+		return
+	}
+	component := status.Components[name]
+	component.CanaryHealthySince = nil
+	status.Components[name] = component
+}
+
+// IsUpdatePaused reports whether the operator should hold a canary rollout
+// in place after its canary wave has gone healthy, waiting for an operator
+// to clear Spec.PauseCondition.
+func IsUpdatePaused(resource *ytv1.Ytsaurus) bool {
+	return resource.Spec.PauseCondition != nil && *resource.Spec.PauseCondition
+}
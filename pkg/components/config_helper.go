@@ -0,0 +1,93 @@
+package components
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/ytsaurus/yt-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/yt-k8s-operator/pkg/labeller"
+	"github.com/ytsaurus/yt-k8s-operator/pkg/resources"
+	"github.com/ytsaurus/yt-k8s-operator/pkg/ytconfig"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ConfigHelper renders a component's YSON config into a ConfigMap and
+// tracks whether the rendered config has drifted from what is already live.
+type ConfigHelper struct {
+	configMap *resources.ConfigMap
+
+	fileName        string
+	configOverrides []string
+	generator       ytconfig.GeneratorFunc
+
+	// lastChecksum is the last successfully computed Checksum, returned by
+	// Checksum on a transient generator error instead of "" so a render
+	// failure doesn't get mistaken for a real config change.
+	lastChecksum string
+}
+
+func NewConfigHelper(
+	l *labeller.Labeller,
+	proxy apiproxy.APIProxy,
+	configMapName, fileName string,
+	configOverrides []string,
+	generator ytconfig.GeneratorFunc,
+) *ConfigHelper {
+	return &ConfigHelper{
+		configMap:       resources.NewConfigMap(configMapName, l, proxy),
+		fileName:        fileName,
+		configOverrides: configOverrides,
+		generator:       generator,
+	}
+}
+
+func (h *ConfigHelper) Fetch(ctx context.Context) error {
+	return resources.Fetch(ctx, h.configMap)
+}
+
+func (h *ConfigHelper) NeedSync() bool {
+	return !resources.Exists(h.configMap)
+}
+
+func (h *ConfigHelper) Build() *corev1.ConfigMap {
+	configMap := h.configMap.Build()
+
+	config, err := h.generator()
+	if err == nil {
+		if configMap.Data == nil {
+			configMap.Data = make(map[string]string)
+		}
+		configMap.Data[h.fileName] = string(config)
+	}
+
+	return configMap
+}
+
+func (h *ConfigHelper) GetFileName() string {
+	return h.fileName
+}
+
+// Checksum computes a SHA-256 over the fully-rendered YSON config plus any
+// ConfigOverrides. server.NeedUpdate compares this against the checksum
+// annotation already stamped on the live StatefulSet's pod template instead
+// of re-fetching and diff-checking the ConfigMap, so the checksum must
+// change whenever anything that ends up in the rendered config changes. On a
+// transient generator error it returns the last successfully computed
+// checksum rather than "", so a render failure doesn't masquerade as a real
+// config change and drive NeedUpdate into a false positive on every
+// reconcile until the error clears.
+func (h *ConfigHelper) Checksum() string {
+	config, err := h.generator()
+	if err != nil {
+		return h.lastChecksum
+	}
+
+	sum := sha256.New()
+	sum.Write(config)
+	for _, override := range h.configOverrides {
+		sum.Write([]byte(override))
+	}
+	h.lastChecksum = hex.EncodeToString(sum.Sum(nil))
+	return h.lastChecksum
+}
@@ -0,0 +1,49 @@
+package components
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConfigHelperChecksumChangesWithConfigOverrides(t *testing.T) {
+	render := func() ([]byte, error) { return []byte("controller_agent_config = {};"), nil }
+
+	base := &ConfigHelper{generator: render, configOverrides: []string{"profile = \"default\";"}}
+	overridden := &ConfigHelper{generator: render, configOverrides: []string{"profile = \"canary\";"}}
+
+	baseSum := base.Checksum()
+	overriddenSum := overridden.Checksum()
+
+	if baseSum == "" || overriddenSum == "" {
+		t.Fatalf("expected non-empty checksums, got %q and %q", baseSum, overriddenSum)
+	}
+	if baseSum == overriddenSum {
+		t.Fatalf("expected checksum to change when ConfigOverrides change, both were %q", baseSum)
+	}
+
+	same := &ConfigHelper{generator: render, configOverrides: []string{"profile = \"default\";"}}
+	if base.Checksum() != same.Checksum() {
+		t.Fatalf("expected checksum to be stable for identical config and overrides")
+	}
+}
+
+func TestConfigHelperChecksumFallsBackToLastGoodOnGeneratorError(t *testing.T) {
+	failing := false
+	render := func() ([]byte, error) {
+		if failing {
+			return nil, errors.New("transient render failure")
+		}
+		return []byte("controller_agent_config = {};"), nil
+	}
+
+	helper := &ConfigHelper{generator: render}
+	goodSum := helper.Checksum()
+	if goodSum == "" {
+		t.Fatal("expected a non-empty checksum on a successful render")
+	}
+
+	failing = true
+	if got := helper.Checksum(); got != goodSum {
+		t.Fatalf("expected Checksum to fall back to the last good checksum %q on error, got %q", goodSum, got)
+	}
+}
@@ -2,13 +2,19 @@ package components
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/go-logr/logr"
 	ytv1 "github.com/ytsaurus/yt-k8s-operator/api/v1"
 	"github.com/ytsaurus/yt-k8s-operator/pkg/apiproxy"
 	"github.com/ytsaurus/yt-k8s-operator/pkg/consts"
 	"github.com/ytsaurus/yt-k8s-operator/pkg/labeller"
+	"github.com/ytsaurus/yt-k8s-operator/pkg/log"
+	"github.com/ytsaurus/yt-k8s-operator/pkg/merge"
 	"github.com/ytsaurus/yt-k8s-operator/pkg/resources"
 	"github.com/ytsaurus/yt-k8s-operator/pkg/ytconfig"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type ControllerAgent struct {
@@ -26,10 +32,12 @@ func NewControllerAgent(cfgen *ytconfig.Generator, ytsaurus *apiproxy.Ytsaurus,
 		ComponentName:  "ControllerAgent",
 	}
 
+	effectiveSpec := merge.InstanceSpec(resource.Spec.Defaults.InstanceSpec, resource.Spec.ControllerAgents.InstanceSpec)
+
 	srv := newServer(
 		&l,
 		ytsaurus,
-		&resource.Spec.ControllerAgents.InstanceSpec,
+		&effectiveSpec,
 		"/usr/bin/ytserver-controller-agent",
 		"ytserver-controller-agent.yson",
 		"ca",
@@ -52,44 +60,162 @@ func (ca *ControllerAgent) Fetch(ctx context.Context) error {
 	return resources.Fetch(ctx, ca.server)
 }
 
+// setCondition records why the component is (or isn't) ready as a
+// Kubernetes-style condition on Ytsaurus.Status.Components[name], so that
+// `kubectl wait --for=condition=...` and Conditions-aware monitoring have
+// something to observe beyond the flat SyncStatus.
+func (ca *ControllerAgent) setCondition(status metav1.ConditionStatus, reason, message string) {
+	apiproxy.SetComponentCondition(&ca.ytsaurus.GetResource().Status, ca.GetName(), metav1.Condition{
+		Type:               ca.GetName() + "Ready",
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: ca.ytsaurus.GetResource().Generation,
+	})
+}
+
+// advanceCanaryRollout steps the StatefulSet partition down from "only the
+// canary wave" toward zero, one UpdateStrategy.MaxUnavailable-sized chunk at
+// a time, only advancing once every replica the partition already exposes
+// has passed its deep readiness probe, an optional PauseAfterCanary
+// cooldown has elapsed, and Spec.PauseCondition isn't set. The actual
+// decision is made by decideCanaryRollout; this just wires it up to
+// apiproxy/Server and persists its verdict on Status.Components[name] so a
+// restarted operator resumes the rollout instead of re-rolling from
+// scratch. It returns a non-nil status when the rollout isn't done yet and
+// the caller should stop here for this reconcile; nil means the rollout has
+// reached partition 0 and doSync should fall through to the regular update
+// path.
+func (ca *ControllerAgent) advanceCanaryRollout(ctx context.Context, logger logr.Logger, strategy *ytv1.UpdateStrategy) (*ComponentStatus, error) {
+	resourceStatus := &ca.ytsaurus.GetResource().Status
+	canaryFloor := ca.server.InstanceCount() - strategy.Partition
+	if canaryFloor < 0 {
+		canaryFloor = 0
+	}
+
+	storedPartition := apiproxy.GetComponentPartition(resourceStatus, ca.GetName(), canaryFloor)
+	probePartition := storedPartition
+	if probePartition > canaryFloor {
+		probePartition = canaryFloor
+	}
+	ready, total := ca.server.RolloutProgress(ctx, probePartition)
+
+	var healthySince *time.Time
+	if t := apiproxy.GetComponentCanaryHealthySince(resourceStatus, ca.GetName()); t != nil {
+		healthySince = &t.Time
+	}
+
+	result := decideCanaryRollout(canaryRolloutInput{
+		instanceCount:      ca.server.InstanceCount(),
+		strategy:           *strategy,
+		storedPartition:    &probePartition,
+		ready:              ready,
+		total:              total,
+		canaryHealthySince: healthySince,
+		now:                time.Now(),
+		manuallyPaused:     apiproxy.IsUpdatePaused(ca.ytsaurus.GetResource()),
+	})
+
+	logger.V(1).Info(result.reason, "partition", result.partition)
+	ca.setCondition(metav1.ConditionFalse, "CanaryRollout", result.reason)
+
+	if result.canaryHealthySince != nil {
+		apiproxy.SetComponentCanaryHealthySince(resourceStatus, ca.GetName(), metav1.NewTime(*result.canaryHealthySince))
+	}
+
+	if result.done {
+		apiproxy.ClearComponentPartition(resourceStatus, ca.GetName())
+		apiproxy.ClearComponentCanaryHealthySince(resourceStatus, ca.GetName())
+		return nil, nil
+	}
+
+	apiproxy.SetComponentPartition(resourceStatus, ca.GetName(), result.partition)
+	ca.server.SetRolloutPartition(result.partition)
+
+	status := WaitingStatus(SyncStatusUpdating, result.reason)
+	return &status, nil
+}
+
 func (ca *ControllerAgent) doSync(ctx context.Context, dry bool) (ComponentStatus, error) {
 	var err error
+	logger := log.FromContext(ctx).WithValues("component", ca.GetName())
 
 	if ytv1.IsReadyToUpdateClusterState(ca.ytsaurus.GetClusterState()) && ca.server.needUpdate() {
+		logger.V(1).Info("component needs a local update")
+		ca.setCondition(metav1.ConditionFalse, "ConfigReloadPending", "component config or image changed and needs a local update")
 		return SimpleStatus(SyncStatusNeedLocalUpdate), err
 	}
 
 	if ca.ytsaurus.GetClusterState() == ytv1.ClusterStateUpdating {
+		if strategy := ca.server.CanaryStrategy(); strategy != nil {
+			if status, err := ca.advanceCanaryRollout(ctx, logger, strategy); status != nil {
+				return *status, err
+			}
+		}
+
 		if status, err := handleUpdatingClusterState(ctx, ca.ytsaurus, ca, &ca.localComponent, ca.server, dry); status != nil {
 			return *status, err
 		}
 	}
 
-	if !IsRunningStatus(ca.master.Status(ctx).SyncStatus) {
-		return WaitingStatus(SyncStatusBlocked, ca.master.GetName()), err
+	masterStatus, err := ca.master.Status(ctx)
+	if err != nil {
+		return ComponentStatus{}, err
+	}
+	if !IsRunningStatus(masterStatus.SyncStatus) {
+		logger.V(1).Info("waiting for master", "master", ca.master.GetName())
+		ca.setCondition(metav1.ConditionFalse, "MasterNotReady", fmt.Sprintf("waiting for %s to become ready", ca.master.GetName()))
+		return WaitingStatus(SyncStatusBlocked, ca.master.GetName()), nil
 	}
 
 	if ca.NeedSync() {
 		if !dry {
 			err = ca.server.Sync(ctx)
 		}
+		logger.V(1).Info("syncing component resources")
+		ca.setCondition(metav1.ConditionFalse, "ComponentsSyncPending", "component resources are being synced")
 		return WaitingStatus(SyncStatusPending, "components"), err
 	}
 
 	if !ca.server.arePodsReady(ctx) {
-		return WaitingStatus(SyncStatusBlocked, "pods"), err
+		logger.V(1).Info("waiting for pods to become ready")
+		ca.setCondition(metav1.ConditionFalse, "PodsNotReady", "waiting for pods to become ready")
+		return WaitingStatus(SyncStatusBlocked, "pods"), nil
 	}
 
-	return SimpleStatus(SyncStatusReady), err
+	if ready, reason := ca.server.IsReady(ctx); !ready {
+		logger.V(1).Info("waiting for deep readiness probe", "reason", reason)
+		ca.setCondition(metav1.ConditionFalse, "OrchidNotReady", reason)
+		return WaitingStatus(SyncStatusBlocked, reason), nil
+	}
+
+	if registered, reason := ca.isRegisteredWithMaster(ctx); !registered {
+		logger.V(1).Info("controller agent not yet registered with master", "reason", reason)
+		ca.setCondition(metav1.ConditionFalse, "NotRegistered", reason)
+		return WaitingStatus(SyncStatusBlocked, reason), nil
+	}
+
+	logger.V(1).Info("component is ready")
+	ca.setCondition(metav1.ConditionTrue, "ComponentReady", "component is ready")
+	return SimpleStatus(SyncStatusReady), nil
 }
 
-func (ca *ControllerAgent) Status(ctx context.Context) ComponentStatus {
-	status, err := ca.doSync(ctx, true)
+// isRegisteredWithMaster checks //sys/controller_agents/instances on the
+// master, since a controller agent can pass its Orchid probe before the
+// master has actually picked it up.
+func (ca *ControllerAgent) isRegisteredWithMaster(ctx context.Context) (bool, string) {
+	registered, err := ca.cfgen.IsControllerAgentRegistered(ctx, ca.GetName())
 	if err != nil {
-		panic(err)
+		return false, fmt.Sprintf("failed to query master for registration: %s", err)
+	}
+	if !registered {
+		return false, "not listed in //sys/controller_agents/instances yet"
 	}
+	return true, ""
+}
 
-	return status
+func (ca *ControllerAgent) Status(ctx context.Context) (ComponentStatus, error) {
+	return ca.doSync(ctx, true)
 }
 
 func (ca *ControllerAgent) Sync(ctx context.Context) error {
@@ -0,0 +1,84 @@
+package components
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ReadinessChecker probes a running instance beyond plain Kubernetes pod
+// readiness. A pod's readiness probe only tells us the container accepted a
+// TCP/HTTP connection; it says nothing about whether the YT process inside
+// has actually registered with the master or finished loading its config.
+type ReadinessChecker interface {
+	// IsReady reports whether addr (a pod's DNS name) is
+	// deeply ready, and if not, a human-readable reason why.
+	IsReady(ctx context.Context, addr string) (ready bool, reason string)
+}
+
+// orchidServiceStatus is the subset of YT's /orchid/service response we care
+// about for readiness purposes.
+type orchidServiceStatus struct {
+	Version string `json:"version"`
+	Start   string `json:"start_time"`
+}
+
+// orchidReadinessChecker probes a component's monitoring endpoint by issuing
+// an in-cluster HTTP GET against /orchid/service and parsing the response,
+// matching the pattern of polling object-specific readiness rather than
+// trusting Pod status alone.
+type orchidReadinessChecker struct {
+	httpClient *http.Client
+	port       int
+}
+
+// NewOrchidReadinessChecker returns the default ReadinessChecker, probing the
+// monitoring HTTP port every component already exposes with the given
+// per-probe timeout.
+func NewOrchidReadinessChecker(monitoringPort int, timeout time.Duration) ReadinessChecker {
+	return &orchidReadinessChecker{
+		httpClient: &http.Client{Timeout: timeout},
+		port:       monitoringPort,
+	}
+}
+
+func (c *orchidReadinessChecker) IsReady(ctx context.Context, addr string) (bool, string) {
+	// Orchid serves YSON text by default; ask for its JSON representation
+	// explicitly so encoding/json can parse the response.
+	url := fmt.Sprintf("http://%s:%d/orchid/service?output_format=json", addr, c.port)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, err.Error()
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Sprintf("orchid request failed: %s", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, fmt.Sprintf("failed to read orchid response: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Sprintf("orchid returned status %d", resp.StatusCode)
+	}
+
+	var status orchidServiceStatus
+	if err := json.Unmarshal(body, &status); err != nil {
+		return false, fmt.Sprintf("failed to parse orchid response: %s", err)
+	}
+
+	if status.Start == "" {
+		return false, "orchid service has not reported a start time yet"
+	}
+
+	return true, ""
+}
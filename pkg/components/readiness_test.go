@@ -0,0 +1,91 @@
+package components
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestOrchidChecker(t *testing.T, handler http.HandlerFunc) (ReadinessChecker, string) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("failed to parse test server URL: %s", err)
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		t.Fatalf("failed to parse test server port: %s", err)
+	}
+
+	return NewOrchidReadinessChecker(port, time.Second), u.Hostname()
+}
+
+func TestOrchidReadinessCheckerReadyWhenServiceHasStarted(t *testing.T) {
+	checker, addr := newTestOrchidChecker(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("output_format"); got != "json" {
+			t.Errorf("expected output_format=json, got %q", got)
+		}
+		w.Write([]byte(`{"version":"1.2.3","start_time":"2024-01-01T00:00:00Z"}`))
+	})
+
+	ready, reason := checker.IsReady(context.Background(), addr)
+	if !ready {
+		t.Fatalf("expected ready, got not ready: %s", reason)
+	}
+}
+
+func TestOrchidReadinessCheckerNotReadyWithoutStartTime(t *testing.T) {
+	checker, addr := newTestOrchidChecker(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"version":"1.2.3"}`))
+	})
+
+	ready, reason := checker.IsReady(context.Background(), addr)
+	if ready {
+		t.Fatalf("expected not ready when start_time is missing")
+	}
+	if !strings.Contains(reason, "start time") {
+		t.Fatalf("expected reason to mention missing start time, got %q", reason)
+	}
+}
+
+func TestOrchidReadinessCheckerNotReadyOnNonOKStatus(t *testing.T) {
+	checker, addr := newTestOrchidChecker(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	ready, reason := checker.IsReady(context.Background(), addr)
+	if ready {
+		t.Fatalf("expected not ready on a non-200 response")
+	}
+	if !strings.Contains(reason, "503") {
+		t.Fatalf("expected reason to mention the status code, got %q", reason)
+	}
+}
+
+func TestOrchidReadinessCheckerRespectsTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte(`{"version":"1.2.3","start_time":"2024-01-01T00:00:00Z"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	u, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(u.Port())
+	checker := NewOrchidReadinessChecker(port, time.Millisecond)
+
+	ready, reason := checker.IsReady(context.Background(), u.Hostname())
+	if ready {
+		t.Fatalf("expected the probe to time out before the handler responds")
+	}
+	if !strings.Contains(reason, "orchid request failed") {
+		t.Fatalf("expected a request-failed reason, got %q", reason)
+	}
+}
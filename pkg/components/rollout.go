@@ -0,0 +1,95 @@
+package components
+
+import (
+	"fmt"
+	"time"
+
+	ytv1 "github.com/ytsaurus/yt-k8s-operator/api/v1"
+)
+
+// canaryRolloutInput is everything decideCanaryRollout needs to decide the
+// next step of a canary rollout. It's kept free of Server/apiproxy
+// dependencies so the state machine can be unit tested without a live
+// cluster.
+type canaryRolloutInput struct {
+	instanceCount      int32
+	strategy           ytv1.UpdateStrategy
+	storedPartition    *int32
+	ready, total       int32
+	canaryHealthySince *time.Time
+	now                time.Time
+	manuallyPaused     bool
+}
+
+// canaryRolloutResult is decideCanaryRollout's verdict for this reconcile.
+type canaryRolloutResult struct {
+	// partition is the StatefulSet partition to apply (and persist) now.
+	partition int32
+	// done is true once the rollout has reached partition 0 and the caller
+	// should clear the persisted rollout state and fall through to the
+	// regular update path.
+	done bool
+	// canaryHealthySince is what should be persisted as the component's
+	// CanaryHealthySince going forward (nil leaves what is already persisted
+	// untouched; it is cleared only once the rollout is done).
+	canaryHealthySince *time.Time
+	// reason explains the current state for logging/Conditions.
+	reason string
+}
+
+// decideCanaryRollout is the partition/pause/cooldown state machine behind
+// ControllerAgent.advanceCanaryRollout: it steps the partition down from
+// "only the canary wave" toward zero, one MaxUnavailable-sized chunk at a
+// time, gated on the canary wave's deep readiness, an optional
+// PauseAfterCanary cooldown, and the manual PauseCondition gate.
+func decideCanaryRollout(in canaryRolloutInput) canaryRolloutResult {
+	canaryFloor := in.instanceCount - in.strategy.Partition
+	if canaryFloor < 0 {
+		canaryFloor = 0
+	}
+
+	partition := canaryFloor
+	if in.storedPartition != nil && *in.storedPartition <= canaryFloor {
+		partition = *in.storedPartition
+	}
+
+	progress := fmt.Sprintf("RolloutProgress: %d/%d canary healthy", in.ready, in.total)
+
+	if in.total == 0 || in.ready < in.total {
+		return canaryRolloutResult{partition: partition, reason: progress}
+	}
+
+	var healthySince *time.Time
+	if partition == canaryFloor {
+		healthySince = in.canaryHealthySince
+		if healthySince == nil {
+			since := in.now
+			healthySince = &since
+		}
+
+		if in.strategy.PauseAfterCanary != nil {
+			if remaining := in.strategy.PauseAfterCanary.Duration - in.now.Sub(*healthySince); remaining > 0 {
+				reason := fmt.Sprintf("canary healthy, cooling down for %s more", remaining.Round(time.Second))
+				return canaryRolloutResult{partition: partition, canaryHealthySince: healthySince, reason: reason}
+			}
+		}
+	}
+
+	if in.manuallyPaused {
+		return canaryRolloutResult{
+			partition:          partition,
+			canaryHealthySince: healthySince,
+			reason:             "canary is healthy, waiting for PauseCondition to be cleared",
+		}
+	}
+
+	if partition == 0 {
+		return canaryRolloutResult{partition: 0, done: true, reason: progress}
+	}
+
+	next := partition - in.strategy.MaxUnavailable
+	if next < 0 {
+		next = 0
+	}
+	return canaryRolloutResult{partition: next, reason: progress}
+}
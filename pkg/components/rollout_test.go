@@ -0,0 +1,176 @@
+package components
+
+import (
+	"testing"
+	"time"
+
+	ytv1 "github.com/ytsaurus/yt-k8s-operator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodOrdinal(t *testing.T) {
+	cases := []struct {
+		podName string
+		want    int32
+		ok      bool
+	}{
+		{"ca-0", 0, true},
+		{"ca-9", 9, true},
+		{"ca-controller-agent-3", 3, true},
+		{"ca", 0, false},
+		{"ca-x", 0, false},
+	}
+
+	for _, c := range cases {
+		got, ok := podOrdinal(c.podName)
+		if ok != c.ok || (ok && got != c.want) {
+			t.Errorf("podOrdinal(%q) = (%d, %v), want (%d, %v)", c.podName, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestDecideCanaryRolloutWaitsForCanaryReadiness(t *testing.T) {
+	result := decideCanaryRollout(canaryRolloutInput{
+		instanceCount: 5,
+		strategy:      ytv1.UpdateStrategy{Partition: 2, MaxUnavailable: 1},
+		ready:         1,
+		total:         2,
+		now:           time.Now(),
+	})
+
+	if result.done {
+		t.Fatalf("expected to wait while canary isn't fully healthy, got %+v", result)
+	}
+	if result.partition != 3 {
+		t.Fatalf("expected partition to stay at canaryFloor (3) while waiting, got %d", result.partition)
+	}
+}
+
+func TestDecideCanaryRolloutStepsDownOnceCanaryHealthy(t *testing.T) {
+	result := decideCanaryRollout(canaryRolloutInput{
+		instanceCount: 5,
+		strategy:      ytv1.UpdateStrategy{Partition: 2, MaxUnavailable: 1},
+		ready:         2,
+		total:         2,
+		now:           time.Now(),
+	})
+
+	if result.done {
+		t.Fatalf("expected rollout not to be fully done yet, got %+v", result)
+	}
+	if result.partition != 2 {
+		t.Fatalf("expected partition to step down by MaxUnavailable to 2, got %d", result.partition)
+	}
+}
+
+func TestDecideCanaryRolloutCompletesAtPartitionZero(t *testing.T) {
+	result := decideCanaryRollout(canaryRolloutInput{
+		instanceCount:   5,
+		strategy:        ytv1.UpdateStrategy{Partition: 2, MaxUnavailable: 5},
+		storedPartition: int32Ptr(0),
+		ready:           5,
+		total:           5,
+		now:             time.Now(),
+	})
+
+	if !result.done {
+		t.Fatalf("expected rollout to be done at partition 0, got %+v", result)
+	}
+}
+
+func TestDecideCanaryRolloutHonorsManualPause(t *testing.T) {
+	result := decideCanaryRollout(canaryRolloutInput{
+		instanceCount:  5,
+		strategy:       ytv1.UpdateStrategy{Partition: 2, MaxUnavailable: 1},
+		ready:          2,
+		total:          2,
+		now:            time.Now(),
+		manuallyPaused: true,
+	})
+
+	if result.done {
+		t.Fatalf("expected manual pause to hold the rollout at canaryFloor, got %+v", result)
+	}
+	if result.partition != 3 {
+		t.Fatalf("expected partition to stay at canaryFloor (3) while paused, got %d", result.partition)
+	}
+}
+
+func TestDecideCanaryRolloutResumesOncePauseCleared(t *testing.T) {
+	result := decideCanaryRollout(canaryRolloutInput{
+		instanceCount:  5,
+		strategy:       ytv1.UpdateStrategy{Partition: 2, MaxUnavailable: 1},
+		ready:          2,
+		total:          2,
+		now:            time.Now(),
+		manuallyPaused: false,
+	})
+
+	if result.done {
+		t.Fatalf("expected rollout not to be fully done yet, got %+v", result)
+	}
+	if result.partition != 2 {
+		t.Fatalf("expected partition to step down to 2 once PauseCondition is cleared, got %d", result.partition)
+	}
+}
+
+func TestDecideCanaryRolloutHonorsPauseAfterCanaryCooldown(t *testing.T) {
+	now := time.Now()
+	healthySince := now.Add(-30 * time.Second)
+	strategy := ytv1.UpdateStrategy{
+		Partition:        2,
+		MaxUnavailable:   1,
+		PauseAfterCanary: &metav1.Duration{Duration: time.Minute},
+	}
+
+	waiting := decideCanaryRollout(canaryRolloutInput{
+		instanceCount:      5,
+		strategy:           strategy,
+		ready:              2,
+		total:              2,
+		canaryHealthySince: &healthySince,
+		now:                now,
+	})
+	if waiting.done || waiting.partition != 3 {
+		t.Fatalf("expected cooldown to hold the rollout at canaryFloor (3), got %+v", waiting)
+	}
+
+	afterCooldown := now.Add(31 * time.Second)
+	advanced := decideCanaryRollout(canaryRolloutInput{
+		instanceCount:      5,
+		strategy:           strategy,
+		ready:              2,
+		total:              2,
+		canaryHealthySince: &healthySince,
+		now:                afterCooldown,
+	})
+	if advanced.done {
+		t.Fatalf("expected rollout not to be fully done yet, got %+v", advanced)
+	}
+	if advanced.partition != 2 {
+		t.Fatalf("expected partition to step down to 2 once cooldown elapses, got %d", advanced.partition)
+	}
+}
+
+func TestDecideCanaryRolloutStartsCanaryHealthySinceOnFirstHealthyReconcile(t *testing.T) {
+	now := time.Now()
+	strategy := ytv1.UpdateStrategy{
+		Partition:        2,
+		MaxUnavailable:   1,
+		PauseAfterCanary: &metav1.Duration{Duration: time.Minute},
+	}
+
+	result := decideCanaryRollout(canaryRolloutInput{
+		instanceCount: 5,
+		strategy:      strategy,
+		ready:         2,
+		total:         2,
+		now:           now,
+	})
+
+	if result.canaryHealthySince == nil || !result.canaryHealthySince.Equal(now) {
+		t.Fatalf("expected CanaryHealthySince to be stamped with now on first healthy reconcile, got %+v", result.canaryHealthySince)
+	}
+}
+
+func int32Ptr(v int32) *int32 { return &v }
@@ -2,30 +2,65 @@ package components
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	ytv1 "github.com/ytsaurus/yt-k8s-operator/api/v1"
 	"github.com/ytsaurus/yt-k8s-operator/pkg/apiproxy"
 	"github.com/ytsaurus/yt-k8s-operator/pkg/consts"
 	"github.com/ytsaurus/yt-k8s-operator/pkg/labeller"
+	"github.com/ytsaurus/yt-k8s-operator/pkg/log"
 	"github.com/ytsaurus/yt-k8s-operator/pkg/resources"
 	"github.com/ytsaurus/yt-k8s-operator/pkg/ytconfig"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 )
 
+// Annotations stamped on the StatefulSet's pod template so that Kubernetes
+// rolls the pods whenever the rendered config or image actually changes.
+// A StatefulSet otherwise never restarts pods on its own when its ConfigMap
+// is edited, so the pod template has to carry that information itself.
+const (
+	configChecksumAnnotation = "ytsaurus.tech/config-checksum"
+	imageChecksumAnnotation  = "ytsaurus.tech/image-checksum"
+)
+
+func imageChecksum(image string) string {
+	sum := sha256.Sum256([]byte(image))
+	return hex.EncodeToString(sum[:])
+}
+
 type Server interface {
 	Fetch(ctx context.Context) error
 	NeedSync() bool
 	ArePodsRemoved() bool
 	ArePodsReady(ctx context.Context) bool
+	IsReady(ctx context.Context) (bool, string)
+	CanaryStrategy() *ytv1.UpdateStrategy
+	InstanceCount() int32
+	SetRolloutPartition(partition int32)
+	RolloutProgress(ctx context.Context, partition int32) (ready, total int32)
 	Sync(ctx context.Context) error
 	BuildStatefulSet() *appsv1.StatefulSet
 	RebuildStatefulSet() *appsv1.StatefulSet
 	NeedUpdate() bool
 }
 
+// defaultMonitoringPort is the HTTP port every YT server component exposes
+// its Orchid tree on, used for deep readiness probing.
+const defaultMonitoringPort = 10042
+
+// defaultReadinessTimeout is how long a single deep readiness probe is
+// allowed to take before it's considered failed, unless overridden via
+// InstanceSpec.ReadinessTimeout.
+const defaultReadinessTimeout = 5 * time.Second
+
 // Server represents a typical YT cluster server component, like master or scheduler.
 type server struct {
 	image    string
@@ -42,7 +77,14 @@ type server struct {
 	builtStatefulSet  *appsv1.StatefulSet
 	readyCondition    string
 
-	configHelper *ConfigHelper
+	configHelper     *ConfigHelper
+	readinessChecker ReadinessChecker
+
+	// rolloutPartition overrides the partition buildUpdateStrategy would
+	// otherwise derive from UpdateStrategy.Partition, letting a canary
+	// rollout be advanced step-by-step across reconciles instead of
+	// jumping straight from "just the canary" to "every replica".
+	rolloutPartition *int32
 }
 
 func NewServer(
@@ -55,6 +97,16 @@ func NewServer(
 	if instanceSpec.Image != nil {
 		image = *instanceSpec.Image
 	}
+
+	monitoringPort := defaultMonitoringPort
+	if instanceSpec.MonitoringPort != nil {
+		monitoringPort = int(*instanceSpec.MonitoringPort)
+	}
+	readinessTimeout := defaultReadinessTimeout
+	if instanceSpec.ReadinessTimeout != nil {
+		readinessTimeout = instanceSpec.ReadinessTimeout.Duration
+	}
+
 	return &server{
 		image:        image,
 		labeller:     l,
@@ -80,10 +132,12 @@ func NewServer(
 			configFileName,
 			ytsaurus.GetResource().Spec.ConfigOverrides,
 			generator),
+		readinessChecker: NewOrchidReadinessChecker(monitoringPort, readinessTimeout),
 	}
 }
 
 func (s *server) Fetch(ctx context.Context) error {
+	log.FromContext(ctx).V(1).Info("fetching server resources", "component", s.labeller.ComponentName)
 	return resources.Fetch(ctx, []resources.Fetchable{
 		s.statefulSet,
 		s.configHelper,
@@ -112,31 +166,159 @@ func (s *server) ArePodsRemoved() bool {
 	return !s.statefulSet.NeedSync(0)
 }
 
-func (s *server) imageCorrespondsToSpec() bool {
-	return s.statefulSet.OldObject().(*appsv1.StatefulSet).Spec.Template.Spec.Containers[0].Image == s.image
-}
-
+// NeedUpdate compares the checksum annotations this server would stamp onto
+// a freshly rendered pod template against the ones already present on the
+// live StatefulSet, instead of fetching the ConfigMap and diff-checking the
+// image string. This makes the update decision deterministic and cheap, and
+// correctly detects drift introduced via ConfigOverrides.
 func (s *server) NeedUpdate() bool {
 	if !s.exists() {
 		return false
 	}
 
-	if !s.imageCorrespondsToSpec() {
-		return true
+	oldAnnotations := s.statefulSet.OldObject().(*appsv1.StatefulSet).Spec.Template.ObjectMeta.Annotations
+
+	return oldAnnotations[configChecksumAnnotation] != s.configHelper.Checksum() ||
+		oldAnnotations[imageChecksumAnnotation] != imageChecksum(s.image)
+}
+
+func (s *server) ArePodsReady(ctx context.Context) bool {
+	return s.statefulSet.ArePodsReady(ctx)
+}
+
+// podProbeResult is one pod's outcome from probePodsConcurrently.
+type podProbeResult struct {
+	podName string
+	ready   bool
+	reason  string
+}
+
+// probePodsConcurrently runs a deep readiness probe against every pod name
+// in parallel, so a reconcile over a large fleet costs roughly one probe's
+// timeout rather than replicas * timeout.
+func (s *server) probePodsConcurrently(ctx context.Context, podNames []string) []podProbeResult {
+	results := make([]podProbeResult, len(podNames))
+
+	var wg sync.WaitGroup
+	for i, podName := range podNames {
+		wg.Add(1)
+		go func(i int, podName string) {
+			defer wg.Done()
+			addr := fmt.Sprintf("%s.%s.%s.svc.cluster.local", podName, s.headlessService.Name(), s.labeller.GetNamespace())
+			ready, reason := s.readinessChecker.IsReady(ctx, addr)
+			results[i] = podProbeResult{podName: podName, ready: ready, reason: reason}
+		}(i, podName)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// IsReady performs a deep readiness probe beyond pod readiness: it polls
+// every replica's Orchid tree on the monitoring port, addressing each pod
+// through the headless service, and only reports
+// ready once all of them answer. On failure it names the offending pod so
+// callers can surface which instance is stuck and why.
+func (s *server) IsReady(ctx context.Context) (bool, string) {
+	for _, r := range s.probePodsConcurrently(ctx, s.statefulSet.PodNames()) {
+		if !r.ready {
+			return false, fmt.Sprintf("%s: %s", r.podName, r.reason)
+		}
+	}
+	return true, ""
+}
+
+// CanaryStrategy returns the component's UpdateStrategy when it is a Canary
+// rollout, or nil otherwise.
+func (s *server) CanaryStrategy() *ytv1.UpdateStrategy {
+	strategy := s.instanceSpec.UpdateStrategy
+	if strategy == nil || strategy.Type != ytv1.UpdateStrategyCanary {
+		return nil
+	}
+	return strategy
+}
+
+// InstanceCount returns the desired replica count for this component.
+func (s *server) InstanceCount() int32 {
+	return s.instanceSpec.InstanceCount
+}
+
+// SetRolloutPartition overrides the StatefulSet partition RebuildStatefulSet
+// will use, so a canary rollout can be advanced step-by-step across
+// reconciles instead of always jumping straight to UpdateStrategy.Partition.
+func (s *server) SetRolloutPartition(partition int32) {
+	s.rolloutPartition = &partition
+}
+
+// buildUpdateStrategy translates the component's UpdateStrategy into a
+// StatefulSet RollingUpdate partition. A partition of `replicas - Partition`
+// pins every pod below the partition index to the old template, so only the
+// top `Partition` replicas roll as canaries. Once a rollout is underway,
+// rolloutPartition (set via SetRolloutPartition) takes precedence so the
+// step-by-step progression in ControllerAgent.advanceCanaryRollout controls
+// how far the update has actually been allowed to go.
+func (s *server) buildUpdateStrategy() appsv1.StatefulSetUpdateStrategy {
+	strategy := s.CanaryStrategy()
+	if strategy == nil {
+		return appsv1.StatefulSetUpdateStrategy{Type: appsv1.RollingUpdateStatefulSetStrategyType}
+	}
+
+	partition := s.instanceSpec.InstanceCount - strategy.Partition
+	if s.rolloutPartition != nil {
+		partition = *s.rolloutPartition
+	}
+	if partition < 0 {
+		partition = 0
 	}
 
-	needReload, err := s.configHelper.NeedReload()
+	return appsv1.StatefulSetUpdateStrategy{
+		Type: appsv1.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+			Partition: &partition,
+		},
+	}
+}
+
+// podOrdinal extracts the trailing "-N" ordinal from a StatefulSet pod name.
+func podOrdinal(podName string) (int32, bool) {
+	idx := strings.LastIndex(podName, "-")
+	if idx < 0 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(podName[idx+1:])
 	if err != nil {
-		return false
+		return 0, false
 	}
-	return needReload
+	return int32(n), true
 }
 
-func (s *server) ArePodsReady(ctx context.Context) bool {
-	return s.statefulSet.ArePodsReady(ctx)
+// RolloutProgress reports how many of the replicas at or above partition
+// (the ones the StatefulSet controller has actually started rolling to the
+// new template) have passed their deep readiness probe. Checking only that
+// range, rather than fleet-wide pod readiness, is what lets the caller gate
+// on the canary pods specifically instead of the rest of the fleet, which
+// is typically already ready before the rollout even starts.
+func (s *server) RolloutProgress(ctx context.Context, partition int32) (ready, total int32) {
+	var canaryPods []string
+	for _, podName := range s.statefulSet.PodNames() {
+		ordinal, ok := podOrdinal(podName)
+		if !ok || ordinal < partition {
+			continue
+		}
+		canaryPods = append(canaryPods, podName)
+	}
+
+	for _, r := range s.probePodsConcurrently(ctx, canaryPods) {
+		total++
+		if r.ready {
+			ready++
+		}
+	}
+	return ready, total
 }
 
 func (s *server) Sync(ctx context.Context) (err error) {
+	log.FromContext(ctx).V(1).Info("syncing server resources", "component", s.labeller.ComponentName)
 	_ = s.configHelper.Build()
 	_ = s.headlessService.Build()
 	_ = s.monitoringService.Build()
@@ -166,6 +348,7 @@ func (s *server) RebuildStatefulSet() *appsv1.StatefulSet {
 	statefulSet.Spec.Replicas = &s.instanceSpec.InstanceCount
 	statefulSet.Spec.ServiceName = s.headlessService.Name()
 	statefulSet.Spec.VolumeClaimTemplates = createVolumeClaims(s.instanceSpec.VolumeClaimTemplates)
+	statefulSet.Spec.UpdateStrategy = s.buildUpdateStrategy()
 
 	setHostnameAsFQDN := true
 	statefulSet.Spec.Template.Spec = corev1.PodSpec{
@@ -193,6 +376,13 @@ func (s *server) RebuildStatefulSet() *appsv1.StatefulSet {
 		NodeSelector: s.instanceSpec.NodeSelector,
 		Tolerations:  s.instanceSpec.Tolerations,
 	}
+
+	if statefulSet.Spec.Template.ObjectMeta.Annotations == nil {
+		statefulSet.Spec.Template.ObjectMeta.Annotations = make(map[string]string)
+	}
+	statefulSet.Spec.Template.ObjectMeta.Annotations[configChecksumAnnotation] = s.configHelper.Checksum()
+	statefulSet.Spec.Template.ObjectMeta.Annotations[imageChecksumAnnotation] = imageChecksum(s.image)
+
 	s.builtStatefulSet = statefulSet
 	return statefulSet
 }
@@ -0,0 +1,120 @@
+// Package merge composes the effective InstanceSpec for a component from the
+// cluster-wide Spec.Defaults.InstanceSpec and a per-component override, so
+// users can set things like tolerations, node selectors, or resources once
+// and only override them for the components that need to differ. Image pull
+// secrets live on YtsaurusSpec.ImagePullSecrets, not on InstanceSpec, and
+// are not part of this merge.
+package merge
+
+import (
+	ytv1 "github.com/ytsaurus/yt-k8s-operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// InstanceSpec deep-merges override on top of defaults: maps are merged
+// key-wise, slices of named items (volumes, volume mounts) are appended with
+// de-duplication by name favoring override, tolerations are appended, and
+// scalars are replaced wholesale whenever override sets them (a nil pointer
+// or a zero InstanceCount counts as unset). Locations and
+// VolumeClaimTemplates are taken from override as they are. Neither argument
+// is mutated; the result is a new value.
+func InstanceSpec(defaults, override ytv1.InstanceSpec) ytv1.InstanceSpec {
+	result := override
+
+	if override.InstanceCount == 0 {
+		result.InstanceCount = defaults.InstanceCount
+	}
+	if override.Image == nil {
+		result.Image = defaults.Image
+	}
+	if override.UpdateStrategy == nil {
+		result.UpdateStrategy = defaults.UpdateStrategy
+	}
+	if override.MonitoringPort == nil {
+		result.MonitoringPort = defaults.MonitoringPort
+	}
+	if override.ReadinessTimeout == nil {
+		result.ReadinessTimeout = defaults.ReadinessTimeout
+	}
+	result.Resources = corev1.ResourceRequirements{
+		Limits:   mergeResourceList(defaults.Resources.Limits, override.Resources.Limits),
+		Requests: mergeResourceList(defaults.Resources.Requests, override.Resources.Requests),
+	}
+	if override.Affinity == nil {
+		result.Affinity = defaults.Affinity
+	}
+
+	result.NodeSelector = mergeStringMaps(defaults.NodeSelector, override.NodeSelector)
+	result.Tolerations = mergeTolerations(defaults.Tolerations, override.Tolerations)
+	result.VolumeMounts = mergeVolumeMounts(defaults.VolumeMounts, override.VolumeMounts)
+	result.Volumes = mergeVolumes(defaults.Volumes, override.Volumes)
+
+	return result
+}
+
+func mergeStringMaps(defaults, override map[string]string) map[string]string {
+	if len(defaults) == 0 {
+		return override
+	}
+
+	merged := make(map[string]string, len(defaults)+len(override))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	for k, v := range override {
+		merged[k] = v
+	}
+	return merged
+}
+
+// mergeResourceList merges Limits/Requests key-wise, like mergeStringMaps,
+// so setting only e.g. Limits in an override doesn't silently drop a
+// defaulted Requests (or vice versa).
+func mergeResourceList(defaults, override corev1.ResourceList) corev1.ResourceList {
+	if len(defaults) == 0 {
+		return override
+	}
+
+	merged := make(corev1.ResourceList, len(defaults)+len(override))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	for k, v := range override {
+		merged[k] = v
+	}
+	return merged
+}
+
+func mergeTolerations(defaults, override []corev1.Toleration) []corev1.Toleration {
+	return append(append([]corev1.Toleration{}, defaults...), override...)
+}
+
+func mergeVolumeMounts(defaults, override []corev1.VolumeMount) []corev1.VolumeMount {
+	seen := make(map[string]bool, len(override))
+	for _, v := range override {
+		seen[v.Name] = true
+	}
+
+	merged := make([]corev1.VolumeMount, 0, len(defaults)+len(override))
+	for _, v := range defaults {
+		if !seen[v.Name] {
+			merged = append(merged, v)
+		}
+	}
+	return append(merged, override...)
+}
+
+func mergeVolumes(defaults, override []corev1.Volume) []corev1.Volume {
+	seen := make(map[string]bool, len(override))
+	for _, v := range override {
+		seen[v.Name] = true
+	}
+
+	merged := make([]corev1.Volume, 0, len(defaults)+len(override))
+	for _, v := range defaults {
+		if !seen[v.Name] {
+			merged = append(merged, v)
+		}
+	}
+	return append(merged, override...)
+}
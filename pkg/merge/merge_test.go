@@ -0,0 +1,60 @@
+package merge
+
+import (
+	"testing"
+
+	ytv1 "github.com/ytsaurus/yt-k8s-operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestInstanceSpecMergesResourcesKeyWise(t *testing.T) {
+	defaults := ytv1.InstanceSpec{
+		Resources: corev1.ResourceRequirements{
+			Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+		},
+	}
+	override := ytv1.InstanceSpec{
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+		},
+	}
+
+	merged := InstanceSpec(defaults, override)
+
+	if got := merged.Resources.Limits[corev1.ResourceCPU]; got.String() != "4" {
+		t.Errorf("expected override CPU limit to win, got %s", got.String())
+	}
+	if mem, ok := merged.Resources.Requests[corev1.ResourceMemory]; !ok || mem.String() != "1Gi" {
+		t.Errorf("expected memory request to be inherited from defaults, got %v (present=%v)", mem, ok)
+	}
+}
+
+func TestInstanceSpecMergesVolumeMountsWithOverridePrecedence(t *testing.T) {
+	defaults := ytv1.InstanceSpec{
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "data", MountPath: "/yt/disk1"},
+			{Name: "logs", MountPath: "/yt/logs"},
+		},
+	}
+	override := ytv1.InstanceSpec{
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "data", MountPath: "/yt/disk-override"},
+		},
+	}
+
+	merged := InstanceSpec(defaults, override)
+
+	byName := make(map[string]string, len(merged.VolumeMounts))
+	for _, m := range merged.VolumeMounts {
+		byName[m.Name] = m.MountPath
+	}
+
+	if byName["data"] != "/yt/disk-override" {
+		t.Errorf("expected override volume mount to win, got %q", byName["data"])
+	}
+	if byName["logs"] != "/yt/logs" {
+		t.Errorf("expected default-only volume mount to be inherited, got %q", byName["logs"])
+	}
+}
@@ -0,0 +1,55 @@
+// Package webhook hosts the admission webhooks for the Ytsaurus CRD. It's
+// kept separate from api/v1 so it can depend on pkg/merge without api/v1
+// importing pkg/merge back (pkg/merge already imports api/v1 for
+// ytv1.InstanceSpec).
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	ytv1 "github.com/ytsaurus/yt-k8s-operator/api/v1"
+	"github.com/ytsaurus/yt-k8s-operator/pkg/merge"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// YtsaurusValidator validates a Ytsaurus resource and, alongside whatever
+// validation rules apply, stamps the defaults-merged InstanceSpec for every
+// component onto status.effectiveSpec, so `kubectl get ytsaurus -o yaml`
+// shows operators exactly what was applied instead of making them mentally
+// merge Spec.Defaults by hand.
+type YtsaurusValidator struct{}
+
+// SetupYtsaurusWebhook registers the validating webhook with the manager.
+func SetupYtsaurusWebhook(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&ytv1.Ytsaurus{}).
+		WithValidator(&YtsaurusValidator{}).
+		Complete()
+}
+
+func (v *YtsaurusValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, recordEffectiveSpec(obj)
+}
+
+func (v *YtsaurusValidator) ValidateUpdate(ctx context.Context, obj, old runtime.Object) (admission.Warnings, error) {
+	return nil, recordEffectiveSpec(obj)
+}
+
+func (v *YtsaurusValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func recordEffectiveSpec(obj runtime.Object) error {
+	resource, ok := obj.(*ytv1.Ytsaurus)
+	if !ok {
+		return fmt.Errorf("expected a Ytsaurus resource, got %T", obj)
+	}
+
+	resource.Status.EffectiveSpec = &ytv1.EffectiveSpec{
+		ControllerAgents: merge.InstanceSpec(resource.Spec.Defaults.InstanceSpec, resource.Spec.ControllerAgents.InstanceSpec),
+	}
+	return nil
+}
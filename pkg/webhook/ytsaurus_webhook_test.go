@@ -0,0 +1,32 @@
+package webhook
+
+import (
+	"testing"
+
+	ytv1 "github.com/ytsaurus/yt-k8s-operator/api/v1"
+)
+
+func TestRecordEffectiveSpecMergesDefaultsIntoStatus(t *testing.T) {
+	instanceCount := int32(3)
+	resource := &ytv1.Ytsaurus{
+		Spec: ytv1.YtsaurusSpec{
+			Defaults: ytv1.ComponentSpec{
+				InstanceSpec: ytv1.InstanceSpec{InstanceCount: 1},
+			},
+			ControllerAgents: ytv1.ComponentSpec{
+				InstanceSpec: ytv1.InstanceSpec{InstanceCount: instanceCount},
+			},
+		},
+	}
+
+	if err := recordEffectiveSpec(resource); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if resource.Status.EffectiveSpec == nil {
+		t.Fatal("expected status.effectiveSpec to be populated")
+	}
+	if got := resource.Status.EffectiveSpec.ControllerAgents.InstanceCount; got != instanceCount {
+		t.Errorf("expected ControllerAgents override to win, got instanceCount=%d", got)
+	}
+}